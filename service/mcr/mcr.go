@@ -17,22 +17,24 @@
 package mcr
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
-	"slices"
-	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/megaport/megaportgo/config"
 	"github.com/megaport/megaportgo/mega_err"
 	"github.com/megaport/megaportgo/service/product"
-	"github.com/megaport/megaportgo/shared"
 	"github.com/megaport/megaportgo/types"
 )
 
 type MCR struct {
 	*config.Config
 	product *product.Product
+	metrics Metrics
 }
 
 // NewLocation
@@ -40,15 +42,32 @@ func New(cfg *config.Config) *MCR {
 	return &MCR{
 		Config:  cfg,
 		product: product.New(cfg),
+		metrics: noopMetrics{},
+	}
+}
+
+// SetMetrics attaches metrics as the destination for this MCR's counters, histograms
+// and gauges. It's optional; without a call to SetMetrics, observability calls are
+// no-ops. Pass a *PrometheusMetrics built with NewPrometheusMetrics to collect into
+// Prometheus, or your own Metrics implementation to plug into another stack.
+func (m *MCR) SetMetrics(metrics Metrics) {
+	if metrics == nil {
+		metrics = noopMetrics{}
 	}
+	m.metrics = metrics
 }
 
 // BuyMCR purchases an MCR.
 func (m *MCR) BuyMCR(locationID int, name string, term int, portSpeed int, mcrASN int) (string, error) {
+	_, span := tracer.Start(context.Background(), "MCR.BuyMCR", trace.WithAttributes(
+		attribute.Int("mcr.location_id", locationID),
+	))
+	defer span.End()
+
 	orderConfig := types.MCROrderConfig{}
 
 	if term != 1 && term != 12 && term != 24 && term != 36 {
-		return "", errors.New(mega_err.ERR_TERM_NOT_VALID)
+		return "", recordErr(span, errors.New(mega_err.ERR_TERM_NOT_VALID))
 	}
 
 	if mcrASN != 0 {
@@ -56,7 +75,7 @@ func (m *MCR) BuyMCR(locationID int, name string, term int, portSpeed int, mcrAS
 	}
 
 	if portSpeed != 1000 && portSpeed != 2500 && portSpeed != 5000 && portSpeed != 10000 {
-		return "", errors.New(mega_err.ERR_MCR_INVALID_PORT_SPEED)
+		return "", recordErr(span, errors.New(mega_err.ERR_MCR_INVALID_PORT_SPEED))
 	}
 
 	order := []types.MCROrder{
@@ -73,23 +92,28 @@ func (m *MCR) BuyMCR(locationID int, name string, term int, portSpeed int, mcrAS
 	requestBody, marshalErr := json.Marshal(order)
 
 	if marshalErr != nil {
-		return "", marshalErr
+		return "", recordErr(span, marshalErr)
 	}
 
 	body, resErr := m.product.ExecuteOrder(&requestBody)
 
 	if resErr != nil {
-		return "", resErr
+		m.metrics.IncAPIErrors("BuyMCR", 0)
+		return "", recordErr(span, resErr)
 	}
 
 	orderInfo := types.MCROrderResponse{}
 	unmarshalErr := json.Unmarshal(*body, &orderInfo)
 
 	if unmarshalErr != nil {
-		return "", unmarshalErr
+		return "", recordErr(span, unmarshalErr)
 	}
 
-	return orderInfo.Data[0].TechnicalServiceUID, nil
+	uid := orderInfo.Data[0].TechnicalServiceUID
+	span.SetAttributes(attribute.String("mcr.id", uid))
+	m.metrics.IncOrders()
+
+	return uid, nil
 }
 
 // CreatePrefixFilterList creates a Prefix Filter List on an MCR.
@@ -100,27 +124,43 @@ func (m *MCR) CreatePrefixFilterList(id string, prefixFilterList types.MCRPrefix
 
 // GetMCRDetails get the details of an MCR.
 func (m *MCR) GetMCRDetails(id string) (types.MCR, error) {
+	return m.getMCRDetails(context.Background(), id)
+}
+
+// getMCRDetails is GetMCRDetails with the span parented to ctx instead of always
+// starting a new root span, so a caller already inside a span (such as wait's poll
+// loop) produces one coherent trace rather than a disconnected root span per call.
+func (m *MCR) getMCRDetails(ctx context.Context, id string) (types.MCR, error) {
+	_, span := tracer.Start(ctx, "MCR.GetMCRDetails", trace.WithAttributes(
+		attribute.String("mcr.id", id),
+	))
+	defer span.End()
+
 	url := "/v2/product/" + id
+	span.SetAttributes(attribute.String("http.url", url), attribute.String("http.method", "GET"))
+
 	response, err := m.Config.MakeAPICall("GET", url, nil)
 	defer response.Body.Close()
 
 	isError, parsedError := m.Config.IsErrorResponse(response, &err, 200)
 
 	if isError {
-		return types.MCR{}, parsedError
+		span.SetAttributes(attribute.Int("http.status_code", response.StatusCode))
+		m.metrics.IncAPIErrors("GetMCRDetails", response.StatusCode)
+		return types.MCR{}, recordErr(span, parsedError)
 	}
 
 	body, fileErr := io.ReadAll(response.Body)
 
 	if fileErr != nil {
-		return types.MCR{}, fileErr
+		return types.MCR{}, recordErr(span, fileErr)
 	}
 
 	portDetails := types.MCRResponse{}
 	unmarshalErr := json.Unmarshal(body, &portDetails)
 
 	if unmarshalErr != nil {
-		return types.MCR{}, unmarshalErr
+		return types.MCR{}, recordErr(span, unmarshalErr)
 	}
 
 	return portDetails.Data, nil
@@ -128,36 +168,42 @@ func (m *MCR) GetMCRDetails(id string) (types.MCR, error) {
 
 // ModifyMCR modifies an MCR.
 func (m *MCR) ModifyMCR(mcrId string, name string, costCentre string, marketplaceVisibility bool) (bool, error) {
-	return m.product.ModifyProduct(mcrId, types.PRODUCT_MCR, name, costCentre, marketplaceVisibility)
+	_, span := tracer.Start(context.Background(), "MCR.ModifyMCR", trace.WithAttributes(
+		attribute.String("mcr.id", mcrId),
+	))
+	defer span.End()
+
+	ok, err := m.product.ModifyProduct(mcrId, types.PRODUCT_MCR, name, costCentre, marketplaceVisibility)
+	if err != nil {
+		m.metrics.IncAPIErrors("ModifyMCR", 0)
+	}
+	return ok, recordErr(span, err)
 }
 
 // ModifyMCR deletes an MCR.
 func (m *MCR) DeleteMCR(id string, deleteNow bool) (bool, error) {
-	return m.product.DeleteProduct(id, deleteNow)
+	_, span := tracer.Start(context.Background(), "MCR.DeleteMCR", trace.WithAttributes(
+		attribute.String("mcr.id", id),
+	))
+	defer span.End()
+
+	ok, err := m.product.DeleteProduct(id, deleteNow)
+	if err != nil {
+		m.metrics.IncAPIErrors("DeleteMCR", 0)
+	}
+	return ok, recordErr(span, err)
 }
 
 // ModifyMCR un-deletes an MCR.
 func (m *MCR) RestoreMCR(id string) (bool, error) {
-	return m.product.RestoreProduct(id)
-}
-
-// DebugWaitMCRLive should be used for testing only.
-func (m *MCR) WaitForMcrProvisioning(mcrId string) (bool, error) {
-	// Try for ~5mins.
-	for i := 0; i < 30; i++ {
-		details, err := m.GetMCRDetails(mcrId)
-		if err != nil {
-			return false, err
-		}
-
-		if slices.Contains(shared.SERVICE_STATE_READY, details.ProvisioningStatus) {
-			return true, nil
-		}
-
-		// Wrong status, wait a bit and try again.
-		m.Log.Debugf("MVE status is %q - waiting", details.ProvisioningStatus)
-		time.Sleep(10 * time.Second)
+	_, span := tracer.Start(context.Background(), "MCR.RestoreMCR", trace.WithAttributes(
+		attribute.String("mcr.id", id),
+	))
+	defer span.End()
+
+	ok, err := m.product.RestoreProduct(id)
+	if err != nil {
+		m.metrics.IncAPIErrors("RestoreMCR", 0)
 	}
-
-	return false, errors.New(mega_err.ERR_MCR_PROVISION_TIMEOUT_EXCEED)
+	return ok, recordErr(span, err)
 }
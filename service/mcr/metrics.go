@@ -0,0 +1,103 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcr
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics is the observability hook the mcr package calls into from its lifecycle
+// operations. An MCR built with New uses a no-op implementation, so instrumentation is
+// entirely opt-in; call MCR.SetMetrics with a PrometheusMetrics (or your own
+// implementation) to start collecting.
+type Metrics interface {
+	// IncOrders counts a successful BuyMCR.
+	IncOrders()
+	// ObserveProvisionDuration records how long a successful WaitForMcrProvisioning
+	// call took to observe a ready MCR, in seconds.
+	ObserveProvisionDuration(seconds float64)
+	// IncAPIErrors counts a failed call to the Megaport API, labelled with the mcr
+	// method that made it and the HTTP status code returned. Only GetMCRDetails passes
+	// a status code; BuyMCR, ModifyMCR, DeleteMCR and RestoreMCR always pass 0.
+	IncAPIErrors(operation string, code int)
+	// AddPendingWaiters adjusts the number of in-flight Wait calls by delta. Called
+	// with +1 when a wait begins and -1 when it ends.
+	AddPendingWaiters(delta float64)
+}
+
+// noopMetrics is the default Metrics implementation: every call is a no-op.
+type noopMetrics struct{}
+
+func (noopMetrics) IncOrders()                               {}
+func (noopMetrics) ObserveProvisionDuration(seconds float64) {}
+func (noopMetrics) IncAPIErrors(operation string, code int)  {}
+func (noopMetrics) AddPendingWaiters(delta float64)          {}
+
+// PrometheusMetrics is the Prometheus Metrics implementation, backed by the
+// client_golang collectors registered under the "mcr_orders_total",
+// "mcr_provision_duration_seconds", "mcr_api_errors_total" and
+// "mcr_pending_waiters" names.
+type PrometheusMetrics struct {
+	orders         prometheus.Counter
+	provisionTime  prometheus.Histogram
+	apiErrors      *prometheus.CounterVec
+	pendingWaiters prometheus.Gauge
+}
+
+// NewPrometheusMetrics builds a PrometheusMetrics and registers its collectors against
+// reg. Pass prometheus.DefaultRegisterer to use the global registry.
+func NewPrometheusMetrics(reg prometheus.Registerer) *PrometheusMetrics {
+	m := &PrometheusMetrics{
+		orders: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "mcr_orders_total",
+			Help: "Number of MCRs successfully ordered via BuyMCR.",
+		}),
+		provisionTime: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "mcr_provision_duration_seconds",
+			Help:    "Time WaitForMcrProvisioning spent waiting for an MCR to become ready.",
+			Buckets: prometheus.ExponentialBuckets(5, 2, 10),
+		}),
+		apiErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "mcr_api_errors_total",
+			Help: "Number of failed Megaport API calls made by the mcr package.",
+		}, []string{"operation", "code"}),
+		pendingWaiters: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "mcr_pending_waiters",
+			Help: "Number of Wait calls currently blocked on an MCR reaching a target state.",
+		}),
+	}
+
+	reg.MustRegister(m.orders, m.provisionTime, m.apiErrors, m.pendingWaiters)
+
+	return m
+}
+
+func (p *PrometheusMetrics) IncOrders() {
+	p.orders.Inc()
+}
+
+func (p *PrometheusMetrics) ObserveProvisionDuration(seconds float64) {
+	p.provisionTime.Observe(seconds)
+}
+
+func (p *PrometheusMetrics) IncAPIErrors(operation string, code int) {
+	p.apiErrors.WithLabelValues(operation, strconv.Itoa(code)).Inc()
+}
+
+func (p *PrometheusMetrics) AddPendingWaiters(delta float64) {
+	p.pendingWaiters.Add(delta)
+}
@@ -0,0 +1,83 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcr
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestPrometheusMetricsIncOrders(t *testing.T) {
+	m := NewPrometheusMetrics(prometheus.NewRegistry())
+
+	m.IncOrders()
+	m.IncOrders()
+
+	if got := testutil.ToFloat64(m.orders); got != 2 {
+		t.Errorf("orders = %v, want 2", got)
+	}
+}
+
+func TestPrometheusMetricsObserveProvisionDuration(t *testing.T) {
+	m := NewPrometheusMetrics(prometheus.NewRegistry())
+
+	m.ObserveProvisionDuration(12.5)
+
+	var metric dto.Metric
+	if err := m.provisionTime.Write(&metric); err != nil {
+		t.Fatalf("provisionTime.Write() error = %v", err)
+	}
+	if got := metric.GetHistogram().GetSampleCount(); got != 1 {
+		t.Errorf("provisionTime observation count = %d, want 1", got)
+	}
+}
+
+func TestPrometheusMetricsIncAPIErrorsLabelsByOperationAndCode(t *testing.T) {
+	m := NewPrometheusMetrics(prometheus.NewRegistry())
+
+	m.IncAPIErrors("GetMCRDetails", 500)
+	m.IncAPIErrors("GetMCRDetails", 500)
+	m.IncAPIErrors("BuyMCR", 0)
+
+	if got := testutil.ToFloat64(m.apiErrors.WithLabelValues("GetMCRDetails", "500")); got != 2 {
+		t.Errorf("GetMCRDetails/500 = %v, want 2", got)
+	}
+	if got := testutil.ToFloat64(m.apiErrors.WithLabelValues("BuyMCR", "0")); got != 1 {
+		t.Errorf("BuyMCR/0 = %v, want 1", got)
+	}
+}
+
+func TestPrometheusMetricsAddPendingWaiters(t *testing.T) {
+	m := NewPrometheusMetrics(prometheus.NewRegistry())
+
+	m.AddPendingWaiters(1)
+	m.AddPendingWaiters(1)
+	m.AddPendingWaiters(-1)
+
+	if got := testutil.ToFloat64(m.pendingWaiters); got != 1 {
+		t.Errorf("pendingWaiters = %v, want 1", got)
+	}
+}
+
+func TestNoopMetricsDoesNotPanic(t *testing.T) {
+	var m noopMetrics
+	m.IncOrders()
+	m.ObserveProvisionDuration(1)
+	m.IncAPIErrors("x", 0)
+	m.AddPendingWaiters(1)
+}
@@ -0,0 +1,230 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/megaport/megaportgo/types"
+)
+
+// OrderBatchItem is a single staged MCR purchase in an OrderBatch.
+//
+// This package only has the mcr.MCR service to build on: the port, MVE and VXC
+// services the original cross-product design calls for don't exist in this tree, so
+// there's nothing to stage a mixed-product atomic order onto. OrderBatch is
+// deliberately scoped down to batching MCR purchases only; extending it to a genuine
+// shared builder on product.Product belongs with whoever adds those sibling services.
+type OrderBatchItem struct {
+	LocationID int
+	Name       string
+	Term       int
+	PortSpeed  int
+	MCRASN     int
+}
+
+// OrderBatchResult is the outcome of a single staged item. Index matches the position
+// the item was added to the batch in. Execute leaves Price zero and fills in
+// TechnicalServiceUID; DryRun does the reverse.
+type OrderBatchResult struct {
+	Index               int
+	TechnicalServiceUID string
+	Price               float64
+}
+
+// OrderBatch stages multiple MCR purchases and submits them to ExecuteOrder as a
+// single atomic order, rather than the one-item-per-call shape BuyMCR uses. Build one
+// with NewOrderBatch, stage items with AddMCR, then call Execute, or DryRun to validate
+// and price the batch without placing it.
+type OrderBatch struct {
+	m     *MCR
+	items []OrderBatchItem
+}
+
+// NewOrderBatch starts an empty batch against m.
+func (m *MCR) NewOrderBatch() *OrderBatch {
+	return &OrderBatch{m: m}
+}
+
+// AddMCR stages an MCR purchase with the same parameters BuyMCR takes. It returns the
+// batch so calls can be chained.
+func (b *OrderBatch) AddMCR(locationID int, name string, term int, portSpeed int, mcrASN int) *OrderBatch {
+	b.items = append(b.items, OrderBatchItem{
+		LocationID: locationID,
+		Name:       name,
+		Term:       term,
+		PortSpeed:  portSpeed,
+		MCRASN:     mcrASN,
+	})
+	return b
+}
+
+// validate checks every staged item against the same rules BuyMCR enforces, plus the
+// ASN range and cross-item location/ASN checks BuyMCR has no batch of sibling items to
+// apply, and builds the MCROrder payload ExecuteOrder expects. It checks every item
+// rather than stopping at the first invalid one, and returns the first failure of each
+// invalid item joined together, labelled with that item's index.
+func (b *OrderBatch) validate() ([]types.MCROrder, error) {
+	if len(b.items) == 0 {
+		return nil, fmt.Errorf("mcr: order batch has no staged items")
+	}
+
+	orders := make([]types.MCROrder, 0, len(b.items))
+	var errs []error
+	stagedASNs := make(map[[2]int]bool, len(b.items))
+
+	for i, item := range b.items {
+		if item.Term != 1 && item.Term != 12 && item.Term != 24 && item.Term != 36 {
+			errs = append(errs, fmt.Errorf("item %d: %s", i, mega_err.ERR_TERM_NOT_VALID))
+			continue
+		}
+
+		if item.PortSpeed != 1000 && item.PortSpeed != 2500 && item.PortSpeed != 5000 && item.PortSpeed != 10000 {
+			errs = append(errs, fmt.Errorf("item %d: %s", i, mega_err.ERR_MCR_INVALID_PORT_SPEED))
+			continue
+		}
+
+		if item.MCRASN < 0 || item.MCRASN > 4294967295 {
+			errs = append(errs, fmt.Errorf("item %d: mcr: ASN %d is outside the valid 1-4294967295 range", i, item.MCRASN))
+			continue
+		}
+
+		// Two MCRs at the same location can't both register the same ASN: Megaport
+		// requires a customer's ASN to be unique per location so eBGP sessions aren't
+		// ambiguous about which MCR they belong to.
+		if item.MCRASN != 0 {
+			key := [2]int{item.LocationID, item.MCRASN}
+			if stagedASNs[key] {
+				errs = append(errs, fmt.Errorf("item %d: mcr: ASN %d is already staged at location %d", i, item.MCRASN, item.LocationID))
+				continue
+			}
+			stagedASNs[key] = true
+		}
+
+		orderConfig := types.MCROrderConfig{}
+		if item.MCRASN != 0 {
+			orderConfig.ASN = item.MCRASN
+		}
+
+		orders = append(orders, types.MCROrder{
+			LocationID: item.LocationID,
+			Name:       item.Name,
+			Term:       item.Term,
+			Type:       "MCR2",
+			PortSpeed:  item.PortSpeed,
+			Config:     orderConfig,
+		})
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return orders, nil
+}
+
+// dryRunResponse is the shape of the validateOnly order response body that DryRun cares
+// about. It's kept local to this file rather than added to the types package, since
+// types.MCROrderResponse (the shape ExecuteOrder returns) has no price field and
+// this repo's types for the real quote/validate response aren't in this tree.
+type dryRunResponse struct {
+	Data []struct {
+		Price float64 `json:"price"`
+	} `json:"data"`
+}
+
+// DryRun validates every staged item and prices the batch without placing it, so
+// callers such as an IaC preview mode can show what Execute would do. It returns the
+// same per-item ordering Execute does, but with TechnicalServiceUID left blank and
+// Price populated from the quote the validateOnly order call returns.
+func (b *OrderBatch) DryRun() ([]OrderBatchResult, error) {
+	orders, err := b.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	requestBody, marshalErr := json.Marshal(orders)
+	if marshalErr != nil {
+		return nil, marshalErr
+	}
+
+	// ExecuteOrder posts the same order shape to product.Product's order endpoint; the
+	// quote/validate path isn't exposed on product.Product in this tree, so DryRun
+	// calls it directly with validateOnly=true rather than placing the order.
+	response, resErr := b.m.Config.MakeAPICall("POST", "/v2/networkdesign/buy?validateOnly=true", &requestBody)
+	defer response.Body.Close()
+
+	isError, parsedError := b.m.Config.IsErrorResponse(response, &resErr, 200)
+	if isError {
+		return nil, parsedError
+	}
+
+	respBody, readErr := io.ReadAll(response.Body)
+	if readErr != nil {
+		return nil, readErr
+	}
+
+	quote := dryRunResponse{}
+	if unmarshalErr := json.Unmarshal(respBody, &quote); unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+
+	results := make([]OrderBatchResult, len(orders))
+	for i := range orders {
+		result := OrderBatchResult{Index: i}
+		if i < len(quote.Data) {
+			result.Price = quote.Data[i].Price
+		}
+		results[i] = result
+	}
+
+	return results, nil
+}
+
+// Execute validates every staged item, then submits the whole batch to ExecuteOrder as
+// one order. The returned results are in the same order items were added in; if the
+// order as a whole fails no items are created and the error describes why.
+func (b *OrderBatch) Execute() ([]OrderBatchResult, error) {
+	orders, err := b.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	requestBody, marshalErr := json.Marshal(orders)
+	if marshalErr != nil {
+		return nil, marshalErr
+	}
+
+	body, resErr := b.m.product.ExecuteOrder(&requestBody)
+	if resErr != nil {
+		return nil, resErr
+	}
+
+	orderInfo := types.MCROrderResponse{}
+	if unmarshalErr := json.Unmarshal(*body, &orderInfo); unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+
+	results := make([]OrderBatchResult, len(orderInfo.Data))
+	for i, item := range orderInfo.Data {
+		results[i] = OrderBatchResult{Index: i, TechnicalServiceUID: item.TechnicalServiceUID}
+	}
+
+	return results, nil
+}
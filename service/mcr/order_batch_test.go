@@ -0,0 +1,110 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcr
+
+import (
+	"testing"
+)
+
+func TestOrderBatchValidateEmpty(t *testing.T) {
+	b := (&MCR{}).NewOrderBatch()
+
+	if _, err := b.validate(); err == nil {
+		t.Error("validate() error = nil, want an error for an empty batch")
+	}
+}
+
+func TestOrderBatchValidateRejectsInvalidTerm(t *testing.T) {
+	b := (&MCR{}).NewOrderBatch().AddMCR(1, "mcr-1", 2, 1000, 0)
+
+	if _, err := b.validate(); err == nil {
+		t.Error("validate() error = nil, want an error for an invalid term")
+	}
+}
+
+func TestOrderBatchValidateRejectsInvalidPortSpeed(t *testing.T) {
+	b := (&MCR{}).NewOrderBatch().AddMCR(1, "mcr-1", 12, 1234, 0)
+
+	if _, err := b.validate(); err == nil {
+		t.Error("validate() error = nil, want an error for an invalid port speed")
+	}
+}
+
+func TestOrderBatchValidateCollectsEveryItemError(t *testing.T) {
+	b := (&MCR{}).NewOrderBatch().
+		AddMCR(1, "mcr-1", 2, 1000, 0).
+		AddMCR(2, "mcr-2", 12, 1234, 0)
+
+	_, err := b.validate()
+	if err == nil {
+		t.Fatal("validate() error = nil, want errors for both items")
+	}
+
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		t.Fatalf("validate() error %v does not support errors.Join unwrapping", err)
+	}
+	if got := len(joined.Unwrap()); got != 2 {
+		t.Errorf("got %d joined errors, want 2 (one per invalid item)", got)
+	}
+}
+
+func TestOrderBatchValidateRejectsInvalidASN(t *testing.T) {
+	b := (&MCR{}).NewOrderBatch().AddMCR(1, "mcr-1", 12, 1000, -1)
+
+	if _, err := b.validate(); err == nil {
+		t.Error("validate() error = nil, want an error for a negative ASN")
+	}
+}
+
+func TestOrderBatchValidateRejectsDuplicateASNAtSameLocation(t *testing.T) {
+	b := (&MCR{}).NewOrderBatch().
+		AddMCR(1, "mcr-1", 12, 1000, 65000).
+		AddMCR(1, "mcr-2", 12, 1000, 65000)
+
+	if _, err := b.validate(); err == nil {
+		t.Error("validate() error = nil, want an error for two items sharing an ASN at the same location")
+	}
+}
+
+func TestOrderBatchValidateAllowsSameASNAtDifferentLocations(t *testing.T) {
+	b := (&MCR{}).NewOrderBatch().
+		AddMCR(1, "mcr-1", 12, 1000, 65000).
+		AddMCR(2, "mcr-2", 12, 1000, 65000)
+
+	if _, err := b.validate(); err != nil {
+		t.Errorf("validate() error = %v, want no error for the same ASN at different locations", err)
+	}
+}
+
+func TestOrderBatchValidateBuildsOrdersForValidItems(t *testing.T) {
+	b := (&MCR{}).NewOrderBatch().
+		AddMCR(1, "mcr-1", 12, 1000, 65000).
+		AddMCR(2, "mcr-2", 24, 10000, 0)
+
+	orders, err := b.validate()
+	if err != nil {
+		t.Fatalf("validate() error = %v", err)
+	}
+	if len(orders) != 2 {
+		t.Fatalf("got %d orders, want 2", len(orders))
+	}
+	if orders[0].Config.ASN != 65000 {
+		t.Errorf("orders[0].Config.ASN = %d, want 65000", orders[0].Config.ASN)
+	}
+	if orders[1].Type != "MCR2" {
+		t.Errorf("orders[1].Type = %q, want MCR2", orders[1].Type)
+	}
+}
@@ -0,0 +1,360 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pki issues and rotates the certificates MCR VXCs use to terminate IPsec and
+// MACsec, via ACME (github.com/go-acme/lego). It handles the background renewal loop
+// and leaves delivering the renewed material to the MCR, and persisting it between
+// restarts, to caller-supplied hooks.
+package pki
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/lego"
+	"github.com/go-acme/lego/v4/registration"
+)
+
+// KeyType selects the private key algorithm used for a certificate request.
+type KeyType string
+
+const (
+	KeyTypeRSA2048   KeyType = "RSA2048"
+	KeyTypeECDSAP256 KeyType = "ECDSAP256"
+)
+
+func (k KeyType) certcryptoKeyType() certcrypto.KeyType {
+	if k == KeyTypeECDSAP256 {
+		return certcrypto.EC256
+	}
+	return certcrypto.RSA2048
+}
+
+// EventKind classifies an Event delivered to AutoCertConfig.OnEvent.
+type EventKind string
+
+const (
+	EventIssued         EventKind = "issued"
+	EventRenewed        EventKind = "renewed"
+	EventIssuanceFailed EventKind = "issuance_failed"
+	EventRenewalFailed  EventKind = "renewal_failed"
+	EventRollback       EventKind = "rollback"
+)
+
+// Event describes a notable occurrence in the lifecycle of an auto-managed
+// certificate.
+type Event struct {
+	McrId string
+	Kind  EventKind
+	Err   error
+}
+
+// Certificate is the key material obtained for an MCR's IPsec/MACsec termination.
+type Certificate struct {
+	Domain      string
+	PrivateKey  []byte // PEM
+	Certificate []byte // PEM, full chain
+	NotAfter    time.Time
+}
+
+// CertStore persists issued certificates between renewer restarts. Implementations
+// must be safe for concurrent use. See NewFileCertStore for an on-disk implementation.
+type CertStore interface {
+	Get(mcrId string) (*Certificate, error)
+	Put(mcrId string, cert *Certificate) error
+}
+
+// PushFunc delivers renewed certificate material to the MCR. Callers typically
+// implement this with mcr.MCR.ModifyMCR or the underlying product API call that
+// updates a VXC's IPsec/MACsec configuration.
+type PushFunc func(mcrId string, cert *Certificate) error
+
+// AutoCertConfig configures automatic issuance and rotation of an MCR's IPsec/MACsec
+// certificate.
+type AutoCertConfig struct {
+	// DirectoryURL is the ACME directory to use, e.g. lego's
+	// lego.LEDirectoryProduction.
+	DirectoryURL string
+	// Domain is the identifier the certificate is issued for.
+	Domain string
+	// DNSProvider answers the ACME dns-01 challenge for Domain.
+	DNSProvider challenge.Provider
+	// KeyType selects the private key algorithm. Defaults to KeyTypeECDSAP256.
+	KeyType KeyType
+	// RenewalThreshold is how long before expiry the renewer requests a new
+	// certificate. Defaults to 30 days.
+	RenewalThreshold time.Duration
+	// CheckInterval is how often the renewer checks the current certificate's expiry.
+	// Defaults to 1 hour.
+	CheckInterval time.Duration
+	// Store persists issued certificates. Defaults to an in-process memory store,
+	// which does not survive a process restart.
+	Store CertStore
+	// Push delivers renewed certificate material to the MCR. Required.
+	Push PushFunc
+	// OnEvent, if set, is called for every issuance, renewal, failure and rollback.
+	OnEvent func(Event)
+}
+
+func (cfg AutoCertConfig) withDefaults() AutoCertConfig {
+	if cfg.KeyType == "" {
+		cfg.KeyType = KeyTypeECDSAP256
+	}
+	if cfg.RenewalThreshold <= 0 {
+		cfg.RenewalThreshold = 30 * 24 * time.Hour
+	}
+	if cfg.CheckInterval <= 0 {
+		cfg.CheckInterval = time.Hour
+	}
+	if cfg.Store == nil {
+		cfg.Store = NewMemoryCertStore()
+	}
+	return cfg
+}
+
+// certifier is the subset of lego's *certificate.Certifier the Manager needs, narrowed
+// to an interface so tests can stub ACME issuance and renewal without a live directory.
+type certifier interface {
+	Obtain(request certificate.ObtainRequest) (*certificate.Resource, error)
+	Renew(resource certificate.Resource, bundle, mustStaple bool, preferredChain string) (*certificate.Resource, error)
+}
+
+// Manager runs the background renewal loop started by EnableAutoCert. Call Stop to
+// shut it down.
+type Manager struct {
+	cfg    AutoCertConfig
+	mcrId  string
+	client certifier
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+// EnableAutoCert issues (if no current certificate exists) and continuously renews an
+// ACME certificate for mcrId, pushing the initial certificate and every renewal to the
+// MCR via cfg.Push. Call Stop on the returned Manager to end the background renewer.
+func EnableAutoCert(mcrId string, cfg AutoCertConfig) (*Manager, error) {
+	cfg = cfg.withDefaults()
+
+	if cfg.Push == nil {
+		return nil, errors.New("pki: AutoCertConfig.Push is required")
+	}
+	if cfg.DNSProvider == nil {
+		return nil, errors.New("pki: AutoCertConfig.DNSProvider is required")
+	}
+
+	user, err := newACMEUser(cfg.KeyType)
+	if err != nil {
+		return nil, fmt.Errorf("pki: generating ACME account key: %w", err)
+	}
+
+	legoCfg := lego.NewConfig(user)
+	legoCfg.CADirURL = cfg.DirectoryURL
+	legoCfg.Certificate.KeyType = cfg.KeyType.certcryptoKeyType()
+
+	client, err := lego.NewClient(legoCfg)
+	if err != nil {
+		return nil, fmt.Errorf("pki: creating ACME client: %w", err)
+	}
+
+	if err := client.Challenge.SetDNS01Provider(cfg.DNSProvider); err != nil {
+		return nil, fmt.Errorf("pki: configuring dns-01 challenge: %w", err)
+	}
+
+	reg, err := client.Registration.Register(registration.RegisterOptions{TermsOfServiceAgreed: true})
+	if err != nil {
+		return nil, fmt.Errorf("pki: registering ACME account: %w", err)
+	}
+	user.registration = reg
+
+	m := &Manager{
+		cfg:    cfg,
+		mcrId:  mcrId,
+		client: client.Certificate,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+
+	if _, err := cfg.Store.Get(mcrId); err != nil {
+		if _, issueErr := m.obtain(); issueErr != nil {
+			return nil, issueErr
+		}
+	}
+
+	go m.renewLoop()
+
+	return m, nil
+}
+
+// Stop ends the background renewal loop. It does not affect the last certificate
+// already pushed to the MCR.
+func (m *Manager) Stop() {
+	close(m.stop)
+	<-m.done
+}
+
+func (m *Manager) renewLoop() {
+	defer close(m.done)
+
+	ticker := time.NewTicker(m.cfg.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.stop:
+			return
+		case <-ticker.C:
+			m.tick()
+		}
+	}
+}
+
+// tick is a single renewLoop iteration: renew the certificate if it's within
+// RenewalThreshold of expiry, otherwise do nothing. Split out from renewLoop so tests
+// can drive it without waiting on CheckInterval.
+func (m *Manager) tick() {
+	cert, err := m.cfg.Store.Get(m.mcrId)
+	if err != nil || time.Until(cert.NotAfter) > m.cfg.RenewalThreshold {
+		return
+	}
+
+	if _, err := m.renew(); err != nil {
+		m.emit(EventRenewalFailed, err)
+	}
+}
+
+func (m *Manager) obtain() (*Certificate, error) {
+	request := certificate.ObtainRequest{
+		Domains: []string{m.cfg.Domain},
+		Bundle:  true,
+	}
+
+	resource, err := m.client.Obtain(request)
+	if err != nil {
+		m.emit(EventIssuanceFailed, err)
+		return nil, fmt.Errorf("pki: obtaining certificate for %s: %w", m.cfg.Domain, err)
+	}
+
+	cert, err := parseCertificate(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	if pushErr := m.cfg.Push(m.mcrId, cert); pushErr != nil {
+		m.emit(EventRollback, pushErr)
+		return nil, fmt.Errorf("pki: pushing obtained certificate to MCR %s: %w", m.mcrId, pushErr)
+	}
+
+	if err := m.cfg.Store.Put(m.mcrId, cert); err != nil {
+		return nil, fmt.Errorf("pki: persisting certificate: %w", err)
+	}
+
+	m.emit(EventIssued, nil)
+	return cert, nil
+}
+
+func (m *Manager) renew() (*Certificate, error) {
+	current, err := m.cfg.Store.Get(m.mcrId)
+	if err != nil {
+		return m.obtain()
+	}
+
+	resource := certificate.Resource{
+		Domain:      current.Domain,
+		PrivateKey:  current.PrivateKey,
+		Certificate: current.Certificate,
+	}
+
+	renewed, err := m.client.Renew(resource, true, false, "")
+	if err != nil {
+		m.emit(EventRenewalFailed, err)
+		return nil, fmt.Errorf("pki: renewing certificate for %s: %w", m.cfg.Domain, err)
+	}
+
+	cert, err := parseCertificate(renewed)
+	if err != nil {
+		return nil, err
+	}
+
+	if pushErr := m.cfg.Push(m.mcrId, cert); pushErr != nil {
+		m.emit(EventRollback, pushErr)
+		return nil, fmt.Errorf("pki: pushing renewed certificate to MCR %s: %w", m.mcrId, pushErr)
+	}
+
+	if err := m.cfg.Store.Put(m.mcrId, cert); err != nil {
+		return nil, fmt.Errorf("pki: persisting certificate: %w", err)
+	}
+
+	m.emit(EventRenewed, nil)
+	return cert, nil
+}
+
+// parseCertificate converts an ACME resource into the Certificate shape the rest of the
+// package uses. It does not persist anything: obtain and renew only write the result to
+// CertStore once cfg.Push has confirmed the MCR actually has it, so a push failure never
+// leaves the store claiming material that was never delivered.
+func parseCertificate(resource *certificate.Resource) (*Certificate, error) {
+	notAfter, err := certcrypto.GetCertificateExpiration(resource.Certificate)
+	if err != nil {
+		return nil, fmt.Errorf("pki: parsing issued certificate: %w", err)
+	}
+
+	return &Certificate{
+		Domain:      resource.Domain,
+		PrivateKey:  resource.PrivateKey,
+		Certificate: resource.Certificate,
+		NotAfter:    notAfter,
+	}, nil
+}
+
+func (m *Manager) emit(kind EventKind, err error) {
+	if m.cfg.OnEvent != nil {
+		m.cfg.OnEvent(Event{McrId: m.mcrId, Kind: kind, Err: err})
+	}
+}
+
+// acmeUser is the minimal registration.User implementation lego's client needs.
+type acmeUser struct {
+	email        string
+	registration *registration.Resource
+	key          crypto.PrivateKey
+}
+
+func newACMEUser(keyType KeyType) (*acmeUser, error) {
+	var key crypto.PrivateKey
+	var err error
+
+	if keyType == KeyTypeECDSAP256 {
+		key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	} else {
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &acmeUser{key: key}, nil
+}
+
+func (u *acmeUser) GetEmail() string                        { return u.email }
+func (u *acmeUser) GetRegistration() *registration.Resource { return u.registration }
+func (u *acmeUser) GetPrivateKey() crypto.PrivateKey        { return u.key }
@@ -0,0 +1,281 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pki
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/certificate"
+)
+
+// fakeCertifier is a certifier stub that hands back a fixed Resource (or a fixed
+// error) without talking to a real ACME directory.
+type fakeCertifier struct {
+	obtained  *certificate.Resource
+	renewed   *certificate.Resource
+	obtainErr error
+	renewErr  error
+}
+
+func (f *fakeCertifier) Obtain(certificate.ObtainRequest) (*certificate.Resource, error) {
+	return f.obtained, f.obtainErr
+}
+
+func (f *fakeCertifier) Renew(certificate.Resource, bool, bool, string) (*certificate.Resource, error) {
+	return f.renewed, f.renewErr
+}
+
+// selfSignedResource returns a certificate.Resource whose Certificate field
+// certcrypto.GetCertificateExpiration can parse, expiring at notAfter.
+func selfSignedResource(t *testing.T, domain string, notAfter time.Time) *certificate.Resource {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating test key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: domain},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("creating test certificate: %v", err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	return &certificate.Resource{
+		Domain:      domain,
+		PrivateKey:  keyPEM,
+		Certificate: certPEM,
+	}
+}
+
+func newTestManager(t *testing.T, cert *fakeCertifier, store CertStore, push PushFunc, events *[]Event) *Manager {
+	t.Helper()
+
+	return &Manager{
+		cfg: AutoCertConfig{
+			Domain:           "mcr.example.com",
+			RenewalThreshold: 30 * 24 * time.Hour,
+			Store:            store,
+			Push:             push,
+			OnEvent: func(e Event) {
+				*events = append(*events, e)
+			},
+		}.withDefaults(),
+		mcrId:  "mcr-1",
+		client: cert,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+}
+
+func TestManagerTickSkipsWhenNotWithinThreshold(t *testing.T) {
+	store := NewMemoryCertStore()
+	notAfter := time.Now().Add(90 * 24 * time.Hour)
+	if err := store.Put("mcr-1", &Certificate{Domain: "mcr.example.com", NotAfter: notAfter}); err != nil {
+		t.Fatalf("seeding store: %v", err)
+	}
+
+	pushed := false
+	var events []Event
+	m := newTestManager(t, &fakeCertifier{}, store, func(string, *Certificate) error {
+		pushed = true
+		return nil
+	}, &events)
+
+	m.tick()
+
+	if pushed {
+		t.Errorf("tick renewed a certificate that wasn't within RenewalThreshold of expiry")
+	}
+	if len(events) != 0 {
+		t.Errorf("events = %v, want none", events)
+	}
+}
+
+func TestManagerTickRenewsWhenWithinThreshold(t *testing.T) {
+	store := NewMemoryCertStore()
+	expiring := time.Now().Add(time.Hour)
+	if err := store.Put("mcr-1", &Certificate{Domain: "mcr.example.com", NotAfter: expiring}); err != nil {
+		t.Fatalf("seeding store: %v", err)
+	}
+
+	renewed := selfSignedResource(t, "mcr.example.com", time.Now().Add(90*24*time.Hour))
+
+	var pushedCert *Certificate
+	var events []Event
+	m := newTestManager(t, &fakeCertifier{renewed: renewed}, store, func(_ string, cert *Certificate) error {
+		pushedCert = cert
+		return nil
+	}, &events)
+
+	m.tick()
+
+	if pushedCert == nil {
+		t.Fatal("tick did not push a renewed certificate")
+	}
+	if len(events) != 1 || events[0].Kind != EventRenewed {
+		t.Errorf("events = %v, want a single EventRenewed", events)
+	}
+}
+
+func TestManagerRenewPushFailureRollsBack(t *testing.T) {
+	store := NewMemoryCertStore()
+	current := &Certificate{Domain: "mcr.example.com", NotAfter: time.Now().Add(time.Hour)}
+	if err := store.Put("mcr-1", current); err != nil {
+		t.Fatalf("seeding store: %v", err)
+	}
+
+	renewed := selfSignedResource(t, "mcr.example.com", time.Now().Add(90*24*time.Hour))
+
+	pushErr := errors.New("mcr rejected the new certificate")
+	var events []Event
+	m := newTestManager(t, &fakeCertifier{renewed: renewed}, store, func(string, *Certificate) error {
+		return pushErr
+	}, &events)
+
+	if _, err := m.renew(); !errors.Is(err, pushErr) {
+		t.Errorf("renew() error = %v, want it to wrap %v", err, pushErr)
+	}
+
+	if len(events) != 1 || events[0].Kind != EventRollback {
+		t.Fatalf("events = %v, want a single EventRollback", events)
+	}
+	if !errors.Is(events[0].Err, pushErr) {
+		t.Errorf("rollback event Err = %v, want %v", events[0].Err, pushErr)
+	}
+
+	stored, err := store.Get("mcr-1")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if !stored.NotAfter.Equal(current.NotAfter) {
+		t.Errorf("store NotAfter = %v, want the pre-failure %v; a failed push must not advance the stored expiry", stored.NotAfter, current.NotAfter)
+	}
+}
+
+func TestManagerObtainPushFailureRollsBack(t *testing.T) {
+	store := NewMemoryCertStore()
+	obtained := selfSignedResource(t, "mcr.example.com", time.Now().Add(90*24*time.Hour))
+
+	pushErr := errors.New("mcr rejected the new certificate")
+	var events []Event
+	m := newTestManager(t, &fakeCertifier{obtained: obtained}, store, func(string, *Certificate) error {
+		return pushErr
+	}, &events)
+
+	if _, err := m.obtain(); !errors.Is(err, pushErr) {
+		t.Errorf("obtain() error = %v, want it to wrap %v", err, pushErr)
+	}
+
+	if len(events) != 1 || events[0].Kind != EventRollback {
+		t.Fatalf("events = %v, want a single EventRollback", events)
+	}
+
+	if _, err := store.Get("mcr-1"); err == nil {
+		t.Error("store has a certificate after a failed initial push; nothing was ever delivered to the MCR")
+	}
+}
+
+func TestMemoryCertStoreRoundTrip(t *testing.T) {
+	store := NewMemoryCertStore()
+
+	if _, err := store.Get("mcr-1"); err == nil {
+		t.Fatal("Get on an empty store returned nil error")
+	}
+
+	want := &Certificate{Domain: "mcr.example.com", NotAfter: time.Now()}
+	if err := store.Put("mcr-1", want); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	got, err := store.Get("mcr-1")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Domain != want.Domain {
+		t.Errorf("Domain = %q, want %q", got.Domain, want.Domain)
+	}
+}
+
+func TestFileCertStoreRoundTrip(t *testing.T) {
+	store, err := NewFileCertStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileCertStore() error = %v", err)
+	}
+
+	want := &Certificate{
+		Domain:      "mcr.example.com",
+		PrivateKey:  []byte("private-key-pem"),
+		Certificate: []byte("certificate-pem"),
+		NotAfter:    time.Now().Truncate(time.Second),
+	}
+	if err := store.Put("mcr-1", want); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	got, err := store.Get("mcr-1")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Domain != want.Domain || string(got.PrivateKey) != string(want.PrivateKey) ||
+		string(got.Certificate) != string(want.Certificate) || !got.NotAfter.Equal(want.NotAfter) {
+		t.Errorf("Get() = %+v, want %+v", got, want)
+	}
+}
+
+func TestFileCertStoreRejectsPathTraversal(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileCertStore(dir)
+	if err != nil {
+		t.Fatalf("NewFileCertStore() error = %v", err)
+	}
+
+	for _, mcrId := range []string{"../escape", "../../etc/passwd", "a/b", ""} {
+		if err := store.Put(mcrId, &Certificate{}); err == nil {
+			t.Errorf("Put(%q) did not error", mcrId)
+		}
+		if _, err := store.Get(mcrId); err == nil {
+			t.Errorf("Get(%q) did not error", mcrId)
+		}
+	}
+
+	entries, err := filepath.Glob(filepath.Join(dir, "..", "*"))
+	if err != nil {
+		t.Fatalf("Glob() error = %v", err)
+	}
+	for _, e := range entries {
+		if e != dir {
+			t.Errorf("path traversal escaped the store directory: found %s", e)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pki
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// memoryCertStore is the default CertStore: it keeps certificates in process memory
+// and does not survive a restart.
+type memoryCertStore struct {
+	mu    sync.Mutex
+	certs map[string]*Certificate
+}
+
+// NewMemoryCertStore returns a CertStore that keeps certificates in memory only.
+func NewMemoryCertStore() CertStore {
+	return &memoryCertStore{certs: make(map[string]*Certificate)}
+}
+
+func (s *memoryCertStore) Get(mcrId string) (*Certificate, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cert, ok := s.certs[mcrId]
+	if !ok {
+		return nil, fmt.Errorf("pki: no certificate stored for MCR %s", mcrId)
+	}
+	return cert, nil
+}
+
+func (s *memoryCertStore) Put(mcrId string, cert *Certificate) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.certs[mcrId] = cert
+	return nil
+}
+
+// fileCertStore persists certificates as JSON files under dir, one per MCR, so a
+// renewer survives process restarts without an external dependency.
+type fileCertStore struct {
+	dir string
+}
+
+// NewFileCertStore returns a CertStore that persists certificates as one JSON file per
+// MCR under dir. dir is created if it does not already exist.
+func NewFileCertStore(dir string) (CertStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("pki: creating cert store directory: %w", err)
+	}
+	return &fileCertStore{dir: dir}, nil
+}
+
+// path returns the on-disk location for mcrId's certificate, rejecting any mcrId that
+// would escape dir (e.g. containing "../" or a path separator) rather than joining it
+// unchecked.
+func (s *fileCertStore) path(mcrId string) (string, error) {
+	if mcrId == "" || mcrId == "." || mcrId == ".." || mcrId != filepath.Base(mcrId) {
+		return "", fmt.Errorf("pki: invalid MCR id %q", mcrId)
+	}
+	return filepath.Join(s.dir, mcrId+".json"), nil
+}
+
+func (s *fileCertStore) Get(mcrId string) (*Certificate, error) {
+	path, err := s.path(mcrId)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("pki: no certificate stored for MCR %s: %w", mcrId, err)
+	}
+
+	cert := &Certificate{}
+	if err := json.Unmarshal(data, cert); err != nil {
+		return nil, fmt.Errorf("pki: decoding stored certificate for MCR %s: %w", mcrId, err)
+	}
+
+	return cert, nil
+}
+
+func (s *fileCertStore) Put(mcrId string, cert *Certificate) error {
+	path, err := s.path(mcrId)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(cert)
+	if err != nil {
+		return fmt.Errorf("pki: encoding certificate for MCR %s: %w", mcrId, err)
+	}
+
+	return os.WriteFile(path, data, 0o600)
+}
@@ -0,0 +1,120 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcr
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/megaport/megaportgo/types"
+)
+
+// ListPrefixFilterLists lists the Prefix Filter Lists configured on an MCR.
+func (m *MCR) ListPrefixFilterLists(mcrId string) ([]types.MCRPrefixFilterList, error) {
+	url := "/v2/product/mcr2/" + mcrId + "/prefixFilterList"
+
+	response, err := m.Config.MakeAPICall("GET", url, nil)
+	defer response.Body.Close()
+
+	isError, parsedError := m.Config.IsErrorResponse(response, &err, 200)
+
+	if isError {
+		return nil, parsedError
+	}
+
+	body, fileErr := io.ReadAll(response.Body)
+
+	if fileErr != nil {
+		return nil, fileErr
+	}
+
+	lists := types.MCRPrefixFilterListsResponse{}
+	unmarshalErr := json.Unmarshal(body, &lists)
+
+	if unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+
+	return lists.Data, nil
+}
+
+// GetPrefixFilterList gets a single Prefix Filter List on an MCR by its ID.
+func (m *MCR) GetPrefixFilterList(mcrId string, prefixFilterListId int) (types.MCRPrefixFilterList, error) {
+	url := fmt.Sprintf("/v2/product/mcr2/%s/prefixFilterList/%d", mcrId, prefixFilterListId)
+
+	response, err := m.Config.MakeAPICall("GET", url, nil)
+	defer response.Body.Close()
+
+	isError, parsedError := m.Config.IsErrorResponse(response, &err, 200)
+
+	if isError {
+		return types.MCRPrefixFilterList{}, parsedError
+	}
+
+	body, fileErr := io.ReadAll(response.Body)
+
+	if fileErr != nil {
+		return types.MCRPrefixFilterList{}, fileErr
+	}
+
+	list := types.MCRPrefixFilterListResponse{}
+	unmarshalErr := json.Unmarshal(body, &list)
+
+	if unmarshalErr != nil {
+		return types.MCRPrefixFilterList{}, unmarshalErr
+	}
+
+	return list.Data, nil
+}
+
+// UpdatePrefixFilterList replaces the contents of an existing Prefix Filter List on an
+// MCR.
+func (m *MCR) UpdatePrefixFilterList(mcrId string, prefixFilterListId int, prefixFilterList types.MCRPrefixFilterList) (bool, error) {
+	url := fmt.Sprintf("/v2/product/mcr2/%s/prefixFilterList/%d", mcrId, prefixFilterListId)
+
+	requestBody, marshalErr := json.Marshal(prefixFilterList)
+
+	if marshalErr != nil {
+		return false, marshalErr
+	}
+
+	response, err := m.Config.MakeAPICall("PUT", url, &requestBody)
+	defer response.Body.Close()
+
+	isError, parsedError := m.Config.IsErrorResponse(response, &err, 200)
+
+	if isError {
+		return false, parsedError
+	}
+
+	return true, nil
+}
+
+// DeletePrefixFilterList deletes a Prefix Filter List from an MCR.
+func (m *MCR) DeletePrefixFilterList(mcrId string, prefixFilterListId int) (bool, error) {
+	url := fmt.Sprintf("/v2/product/mcr2/%s/prefixFilterList/%d", mcrId, prefixFilterListId)
+
+	response, err := m.Config.MakeAPICall("DELETE", url, nil)
+	defer response.Body.Close()
+
+	isError, parsedError := m.Config.IsErrorResponse(response, &err, 200)
+
+	if isError {
+		return false, parsedError
+	}
+
+	return true, nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prefixfilter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/megaport/megaportgo/types"
+)
+
+var (
+	birdFilterRE = regexp.MustCompile(`^filter (\S+) \{$`)
+	// BIRD's condition comes first, then `then`, then the action - the reverse of
+	// `accept if ...`, which isn't valid BIRD syntax.
+	birdEntryRE = regexp.MustCompile(`^\s*if\s+net\s*=\s*(\S+)\s+then\s+(accept|reject);$`)
+)
+
+// parseBird understands a BIRD filter block of the form:
+//
+//	filter CUSTOMER_IN {
+//	    if net = 10.0.0.0/8 then accept;
+//	    if net = 192.168.0.0/16 then reject;
+//	}
+func parseBird(config string) (types.MCRPrefixFilterList, error) {
+	list := types.MCRPrefixFilterList{AddressFamily: "IPv4"}
+
+	for _, rawLine := range strings.Split(config, "\n") {
+		line := strings.TrimSpace(rawLine)
+		if line == "" || line == "}" {
+			continue
+		}
+
+		if matches := birdFilterRE.FindStringSubmatch(line); matches != nil {
+			list.Description = matches[1]
+			continue
+		}
+
+		matches := birdEntryRE.FindStringSubmatch(line)
+		if matches == nil {
+			return types.MCRPrefixFilterList{}, fmt.Errorf("prefixfilter: cannot parse bird line %q", line)
+		}
+
+		action := "permit"
+		if matches[2] == "reject" {
+			action = "deny"
+		}
+
+		list.Entries = append(list.Entries, types.MCRPrefixFilterListEntry{
+			Action: action,
+			Prefix: matches[1],
+		})
+	}
+
+	return list, nil
+}
+
+// renderBird only emits `if net = prefix then action;` conditions, since that's all
+// parseBird understands. A plain net equality test can't express a Ge/Le length range -
+// an entry using one would render as if it were an exact-prefix match, silently
+// admitting or rejecting routes the original entry didn't cover - so renderBird refuses
+// those entries rather than rendering them wrong.
+func renderBird(list types.MCRPrefixFilterList) (string, error) {
+	name := list.Description
+	if name == "" {
+		name = "UNNAMED"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "filter %s {\n", name)
+	for _, entry := range list.Entries {
+		if entry.Ge != 0 || entry.Le != 0 {
+			return "", fmt.Errorf("prefixfilter: bird net equality conditions cannot express a ge/le length range, cannot render %q", entry.Prefix)
+		}
+
+		verb := "accept"
+		if entry.Action == "deny" {
+			verb = "reject"
+		}
+		fmt.Fprintf(&b, "    if net = %s then %s;\n", entry.Prefix, verb)
+	}
+	b.WriteString("}\n")
+
+	return b.String(), nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prefixfilter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/megaport/megaportgo/types"
+)
+
+// ip prefix-list NAME [seq N] permit|deny A.B.C.D/len [ge N] [le N]
+var ciscoEntryRE = regexp.MustCompile(`^ip prefix-list (\S+)(?: seq \d+)? (permit|deny) (\S+)(?: ge (\d+))?(?: le (\d+))?$`)
+
+func parseCisco(config string) (types.MCRPrefixFilterList, error) {
+	list := types.MCRPrefixFilterList{AddressFamily: "IPv4"}
+
+	for _, rawLine := range strings.Split(config, "\n") {
+		line := strings.TrimSpace(rawLine)
+		if line == "" {
+			continue
+		}
+
+		matches := ciscoEntryRE.FindStringSubmatch(line)
+		if matches == nil {
+			return types.MCRPrefixFilterList{}, fmt.Errorf("prefixfilter: cannot parse cisco line %q", line)
+		}
+
+		if list.Description == "" {
+			list.Description = matches[1]
+		}
+
+		entry := types.MCRPrefixFilterListEntry{
+			Action: matches[2],
+			Prefix: matches[3],
+		}
+
+		if matches[4] != "" {
+			entry.Ge, _ = strconv.Atoi(matches[4])
+		}
+		if matches[5] != "" {
+			entry.Le, _ = strconv.Atoi(matches[5])
+		}
+
+		list.Entries = append(list.Entries, entry)
+	}
+
+	return list, nil
+}
+
+func renderCisco(list types.MCRPrefixFilterList) (string, error) {
+	name := list.Description
+	if name == "" {
+		name = "UNNAMED"
+	}
+
+	var b strings.Builder
+	for i, entry := range list.Entries {
+		fmt.Fprintf(&b, "ip prefix-list %s seq %d %s %s", name, (i+1)*5, entry.Action, entry.Prefix)
+		if entry.Ge != 0 {
+			fmt.Fprintf(&b, " ge %d", entry.Ge)
+		}
+		if entry.Le != 0 {
+			fmt.Fprintf(&b, " le %d", entry.Le)
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String(), nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prefixfilter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/megaport/megaportgo/types"
+)
+
+// junosEntryRE matches a `set policy-options prefix-list` line, the form Junos's own
+// `show configuration | display set` produces - one independent, fully-qualified
+// statement per line, with no enclosing braces to track. e.g.:
+//
+//	set policy-options prefix-list CUSTOMER-IN 10.0.0.0/8
+var junosEntryRE = regexp.MustCompile(`^set policy-options prefix-list (\S+) (\S+)$`)
+
+// parseJunos understands `display set` style output, e.g.:
+//
+//	set policy-options prefix-list CUSTOMER-IN 10.0.0.0/8
+//	set policy-options prefix-list CUSTOMER-IN 192.168.0.0/16
+func parseJunos(config string) (types.MCRPrefixFilterList, error) {
+	list := types.MCRPrefixFilterList{AddressFamily: "IPv4"}
+
+	for _, rawLine := range strings.Split(config, "\n") {
+		line := strings.TrimSpace(rawLine)
+		if line == "" {
+			continue
+		}
+
+		matches := junosEntryRE.FindStringSubmatch(line)
+		if matches == nil {
+			return types.MCRPrefixFilterList{}, fmt.Errorf("prefixfilter: cannot parse junos line %q", line)
+		}
+
+		if list.Description == "" {
+			list.Description = matches[1]
+		}
+
+		// A plain Junos prefix-list entry is an exact match, not "up to this length" -
+		// leave Ge/Le unset rather than fabricating a range the original config never
+		// expressed.
+		list.Entries = append(list.Entries, types.MCRPrefixFilterListEntry{
+			Action: "permit",
+			Prefix: matches[2],
+		})
+	}
+
+	return list, nil
+}
+
+// renderJunos only emits `set policy-options prefix-list NAME prefix` lines, since
+// that's all parseJunos understands. It refuses entries it can't render losslessly
+// rather than silently changing their meaning: Junos prefix-lists carry no per-entry
+// action, so a deny entry would render indistinguishable from a permit one, and these
+// lines can't express a Ge/Le length range at all.
+func renderJunos(list types.MCRPrefixFilterList) (string, error) {
+	name := list.Description
+	if name == "" {
+		name = "UNNAMED"
+	}
+
+	var b strings.Builder
+	for _, entry := range list.Entries {
+		if entry.Action != "permit" {
+			return "", fmt.Errorf("prefixfilter: junos prefix-lists have no per-entry action, cannot render %s entry %q", entry.Action, entry.Prefix)
+		}
+		if entry.Ge != 0 || entry.Le != 0 {
+			return "", fmt.Errorf("prefixfilter: junos prefix-lists cannot express a ge/le length range, cannot render %q", entry.Prefix)
+		}
+		fmt.Fprintf(&b, "set policy-options prefix-list %s %s\n", name, entry.Prefix)
+	}
+
+	return b.String(), nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package prefixfilter converts Megaport MCR Prefix Filter Lists to and from the
+// router configuration dialects customers already maintain on their own CPE, so a
+// filter list can be round-tripped between an MCR and a physical router.
+package prefixfilter
+
+import (
+	"fmt"
+
+	"github.com/megaport/megaportgo/types"
+)
+
+// Dialect identifies a router configuration syntax that a Prefix Filter List can be
+// parsed from or rendered to.
+type Dialect string
+
+const (
+	// DialectCisco is Cisco IOS `ip prefix-list` syntax.
+	DialectCisco Dialect = "cisco"
+	// DialectJunos is Junos `policy-options prefix-list` syntax.
+	DialectJunos Dialect = "junos"
+	// DialectBird is BIRD `filter` block syntax made of `if net = prefix then
+	// accept|reject;` conditions. Prefix sets and other filter statements are not
+	// supported.
+	DialectBird Dialect = "bird"
+)
+
+// Parse converts router configuration text written in dialect into a
+// types.MCRPrefixFilterList.
+func Parse(dialect Dialect, config string) (types.MCRPrefixFilterList, error) {
+	switch dialect {
+	case DialectCisco:
+		return parseCisco(config)
+	case DialectJunos:
+		return parseJunos(config)
+	case DialectBird:
+		return parseBird(config)
+	default:
+		return types.MCRPrefixFilterList{}, fmt.Errorf("prefixfilter: unsupported dialect %q", dialect)
+	}
+}
+
+// Render converts a types.MCRPrefixFilterList into router configuration text in the
+// given dialect.
+func Render(dialect Dialect, list types.MCRPrefixFilterList) (string, error) {
+	switch dialect {
+	case DialectCisco:
+		return renderCisco(list)
+	case DialectJunos:
+		return renderJunos(list)
+	case DialectBird:
+		return renderBird(list)
+	default:
+		return "", fmt.Errorf("prefixfilter: unsupported dialect %q", dialect)
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prefixfilter
+
+import (
+	"testing"
+
+	"github.com/megaport/megaportgo/types"
+)
+
+func TestCiscoRoundTrip(t *testing.T) {
+	list := types.MCRPrefixFilterList{
+		Description: "CUSTOMER-IN",
+		Entries: []types.MCRPrefixFilterListEntry{
+			{Action: "permit", Prefix: "10.0.0.0/8", Ge: 9, Le: 24},
+			{Action: "deny", Prefix: "192.168.0.0/16"},
+		},
+	}
+
+	rendered, err := Render(DialectCisco, list)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	parsed, err := Parse(DialectCisco, rendered)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if parsed.Description != list.Description {
+		t.Errorf("Description = %q, want %q", parsed.Description, list.Description)
+	}
+	if len(parsed.Entries) != len(list.Entries) {
+		t.Fatalf("got %d entries, want %d", len(parsed.Entries), len(list.Entries))
+	}
+	for i, entry := range list.Entries {
+		if parsed.Entries[i] != entry {
+			t.Errorf("entry %d = %+v, want %+v", i, parsed.Entries[i], entry)
+		}
+	}
+}
+
+func TestJunosRoundTrip(t *testing.T) {
+	list := types.MCRPrefixFilterList{
+		Description: "CUSTOMER-IN",
+		Entries: []types.MCRPrefixFilterListEntry{
+			{Action: "permit", Prefix: "10.0.0.0/8"},
+		},
+	}
+
+	rendered, err := Render(DialectJunos, list)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	parsed, err := Parse(DialectJunos, rendered)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if len(parsed.Entries) != 1 || parsed.Entries[0] != list.Entries[0] {
+		t.Errorf("got %+v, want %+v", parsed.Entries, list.Entries)
+	}
+}
+
+func TestJunosParseDoesNotFabricateLe(t *testing.T) {
+	parsed, err := Parse(DialectJunos, "set policy-options prefix-list CUSTOMER-IN 10.0.0.0/8")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if len(parsed.Entries) != 1 {
+		t.Fatalf("got %d entries, want 1", len(parsed.Entries))
+	}
+	if got := parsed.Entries[0]; got.Le != 0 || got.Ge != 0 {
+		t.Errorf("entry = %+v, want Ge and Le both unset for an exact-match line", got)
+	}
+}
+
+// TestJunosParseRealDisplaySetOutput feeds Parse the literal output of a real Junos
+// `show configuration policy-options | display set` - every statement on its own line,
+// fully qualified from the top of the hierarchy, independent of any brace nesting - to
+// guard against a grammar that only agrees with itself.
+func TestJunosParseRealDisplaySetOutput(t *testing.T) {
+	config := "set policy-options prefix-list CUSTOMER-IN 10.0.0.0/8\n" +
+		"set policy-options prefix-list CUSTOMER-IN 172.16.0.0/12\n"
+
+	parsed, err := Parse(DialectJunos, config)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if parsed.Description != "CUSTOMER-IN" {
+		t.Errorf("Description = %q, want CUSTOMER-IN", parsed.Description)
+	}
+	want := []types.MCRPrefixFilterListEntry{
+		{Action: "permit", Prefix: "10.0.0.0/8"},
+		{Action: "permit", Prefix: "172.16.0.0/12"},
+	}
+	if len(parsed.Entries) != len(want) {
+		t.Fatalf("got %d entries, want %d", len(parsed.Entries), len(want))
+	}
+	for i, entry := range want {
+		if parsed.Entries[i] != entry {
+			t.Errorf("entry %d = %+v, want %+v", i, parsed.Entries[i], entry)
+		}
+	}
+}
+
+func TestJunosRenderRefusesDeny(t *testing.T) {
+	list := types.MCRPrefixFilterList{
+		Entries: []types.MCRPrefixFilterListEntry{{Action: "deny", Prefix: "10.0.0.0/8"}},
+	}
+
+	if _, err := Render(DialectJunos, list); err == nil {
+		t.Error("Render() error = nil, want an error for a deny entry")
+	}
+}
+
+func TestJunosRenderRefusesGeLe(t *testing.T) {
+	list := types.MCRPrefixFilterList{
+		Entries: []types.MCRPrefixFilterListEntry{{Action: "permit", Prefix: "10.0.0.0/8", Le: 24}},
+	}
+
+	if _, err := Render(DialectJunos, list); err == nil {
+		t.Error("Render() error = nil, want an error for an entry with Le set")
+	}
+}
+
+func TestBirdRoundTrip(t *testing.T) {
+	list := types.MCRPrefixFilterList{
+		Description: "CUSTOMER_IN",
+		Entries: []types.MCRPrefixFilterListEntry{
+			{Action: "permit", Prefix: "10.0.0.0/8"},
+			{Action: "deny", Prefix: "192.168.0.0/16"},
+		},
+	}
+
+	rendered, err := Render(DialectBird, list)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	parsed, err := Parse(DialectBird, rendered)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if len(parsed.Entries) != len(list.Entries) {
+		t.Fatalf("got %d entries, want %d", len(parsed.Entries), len(list.Entries))
+	}
+	for i, entry := range list.Entries {
+		if parsed.Entries[i] != entry {
+			t.Errorf("entry %d = %+v, want %+v", i, parsed.Entries[i], entry)
+		}
+	}
+}
+
+// TestBirdParseRealSyntax feeds Parse real BIRD filter syntax - condition, then
+// `then`, then the action - rather than the reversed `accept if ...;` shape, to guard
+// against a grammar that only agrees with itself.
+func TestBirdParseRealSyntax(t *testing.T) {
+	config := "filter CUSTOMER_IN {\n" +
+		"    if net = 10.0.0.0/8 then accept;\n" +
+		"    if net = 192.168.0.0/16 then reject;\n" +
+		"}\n"
+
+	parsed, err := Parse(DialectBird, config)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	want := []types.MCRPrefixFilterListEntry{
+		{Action: "permit", Prefix: "10.0.0.0/8"},
+		{Action: "deny", Prefix: "192.168.0.0/16"},
+	}
+	if len(parsed.Entries) != len(want) {
+		t.Fatalf("got %d entries, want %d", len(parsed.Entries), len(want))
+	}
+	for i, entry := range want {
+		if parsed.Entries[i] != entry {
+			t.Errorf("entry %d = %+v, want %+v", i, parsed.Entries[i], entry)
+		}
+	}
+}
+
+func TestBirdRenderRefusesGeLe(t *testing.T) {
+	list := types.MCRPrefixFilterList{
+		Entries: []types.MCRPrefixFilterListEntry{{Action: "permit", Prefix: "10.0.0.0/8", Ge: 16}},
+	}
+
+	if _, err := Render(DialectBird, list); err == nil {
+		t.Error("Render() error = nil, want an error for an entry with Ge set")
+	}
+}
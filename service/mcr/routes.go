@@ -0,0 +1,167 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcr
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+
+	"github.com/megaport/megaportgo/types"
+)
+
+// GetBGPPeerStatus returns the looking-glass state of the MCR's BGP sessions. Pass
+// vxcId to narrow the result to the sessions on a single VXC, or leave it blank to
+// return every session on the MCR.
+func (m *MCR) GetBGPPeerStatus(mcrId string, vxcId string) ([]types.BGPPeerStatus, error) {
+	url := "/v2/product/mcr2/" + mcrId + "/bgpStatus"
+	if vxcId != "" {
+		url += "?vxcId=" + vxcId
+	}
+
+	response, err := m.Config.MakeAPICall("GET", url, nil)
+	defer response.Body.Close()
+
+	isError, parsedError := m.Config.IsErrorResponse(response, &err, 200)
+
+	if isError {
+		return nil, parsedError
+	}
+
+	body, fileErr := io.ReadAll(response.Body)
+
+	if fileErr != nil {
+		return nil, fileErr
+	}
+
+	peerStatus := types.BGPPeerStatusResponse{}
+	unmarshalErr := json.Unmarshal(body, &peerStatus)
+
+	if unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+
+	return peerStatus.Data, nil
+}
+
+// GetLearnedRoutes returns the routes the MCR has learned via BGP. filter.Strategy
+// selects between the full RIB (RouteStrategyAll) and best-path-only (RouteStrategyBest,
+// the default when Strategy is left blank).
+func (m *MCR) GetLearnedRoutes(mcrId string, filter types.RouteQuery) ([]types.LearnedRoute, error) {
+	strategy := filter.Strategy
+	if strategy == "" {
+		strategy = types.RouteStrategyBest
+	}
+
+	url := fmt.Sprintf("/v2/product/mcr2/%s/routes?strategy=%s", mcrId, strategy)
+	if filter.VxcID != "" {
+		url += "&vxcId=" + filter.VxcID
+	}
+
+	response, err := m.Config.MakeAPICall("GET", url, nil)
+	defer response.Body.Close()
+
+	isError, parsedError := m.Config.IsErrorResponse(response, &err, 200)
+
+	if isError {
+		return nil, parsedError
+	}
+
+	body, fileErr := io.ReadAll(response.Body)
+
+	if fileErr != nil {
+		return nil, fileErr
+	}
+
+	routes := types.LearnedRoutesResponse{}
+	unmarshalErr := json.Unmarshal(body, &routes)
+
+	if unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+
+	return routes.Data, nil
+}
+
+// GetBestRoutes returns only the best-path route per prefix from the MCR's RIB. It's a
+// convenience wrapper around GetLearnedRoutes using RouteStrategyBest.
+func (m *MCR) GetBestRoutes(mcrId string) ([]types.LearnedRoute, error) {
+	return m.GetLearnedRoutes(mcrId, types.RouteQuery{Strategy: types.RouteStrategyBest})
+}
+
+// DiffLearnedRoutes splits routes into the prefixes filterList would permit and the
+// prefixes it would deny, so a filter list can be validated against what the MCR has
+// actually learned before it's applied.
+func (m *MCR) DiffLearnedRoutes(routes []types.LearnedRoute, filterList types.MCRPrefixFilterList) (permitted []types.LearnedRoute, denied []types.LearnedRoute) {
+	for _, route := range routes {
+		if prefixFilterListPermits(filterList, route.Prefix) {
+			permitted = append(permitted, route)
+		} else {
+			denied = append(denied, route)
+		}
+	}
+
+	return permitted, denied
+}
+
+// prefixFilterListPermits reports whether filterList permits prefix, using first-match
+// semantics over its entries and falling back to deny when nothing matches. Matching
+// is by CIDR containment plus Ge/Le length bounds, the same as a router's
+// `ip prefix-list`, not by string equality: a filter entry is almost always a supernet
+// covering many more-specific learned routes, not a literal copy of one.
+func prefixFilterListPermits(filterList types.MCRPrefixFilterList, prefix string) bool {
+	routeIP, routeNet, err := net.ParseCIDR(prefix)
+	if err != nil {
+		return false
+	}
+	routeLen, _ := routeNet.Mask.Size()
+
+	for _, entry := range filterList.Entries {
+		if prefixFilterEntryMatches(entry, routeIP, routeLen) {
+			return entry.Action == "permit"
+		}
+	}
+
+	return false
+}
+
+// prefixFilterEntryMatches reports whether entry covers a learned route whose network
+// address is routeIP and whose prefix length is routeLen. routeIP must fall within
+// entry's network, and routeLen must fall within entry's Ge/Le length range: ge alone
+// means "entry.Ge through the address family's full length", le alone means "entry's
+// own length through entry.Le", and neither set means an exact-length match, mirroring
+// `ip prefix-list` semantics.
+func prefixFilterEntryMatches(entry types.MCRPrefixFilterListEntry, routeIP net.IP, routeLen int) bool {
+	_, entryNet, err := net.ParseCIDR(entry.Prefix)
+	if err != nil || !entryNet.Contains(routeIP) {
+		return false
+	}
+
+	entryLen, addrBits := entryNet.Mask.Size()
+
+	minLen, maxLen := entryLen, entryLen
+	if entry.Ge != 0 || entry.Le != 0 {
+		maxLen = addrBits
+		if entry.Ge != 0 {
+			minLen = entry.Ge
+		}
+		if entry.Le != 0 {
+			maxLen = entry.Le
+		}
+	}
+
+	return routeLen >= minLen && routeLen <= maxLen
+}
@@ -0,0 +1,116 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcr
+
+import (
+	"testing"
+
+	"github.com/megaport/megaportgo/types"
+)
+
+func TestPrefixFilterListPermits(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []types.MCRPrefixFilterListEntry
+		prefix  string
+		want    bool
+	}{
+		{
+			name:    "more specific route within a permitted supernet",
+			entries: []types.MCRPrefixFilterListEntry{{Action: "permit", Prefix: "10.0.0.0/8", Ge: 9, Le: 24}},
+			prefix:  "10.1.2.0/24",
+			want:    true,
+		},
+		{
+			name:    "route longer than le is denied",
+			entries: []types.MCRPrefixFilterListEntry{{Action: "permit", Prefix: "10.0.0.0/8", Ge: 9, Le: 24}},
+			prefix:  "10.1.2.0/25",
+			want:    false,
+		},
+		{
+			name:    "ge alone extends to /32",
+			entries: []types.MCRPrefixFilterListEntry{{Action: "permit", Prefix: "10.0.0.0/8", Ge: 16}},
+			prefix:  "10.1.2.3/32",
+			want:    true,
+		},
+		{
+			name:    "no ge/le means an exact length match",
+			entries: []types.MCRPrefixFilterListEntry{{Action: "permit", Prefix: "10.0.0.0/8"}},
+			prefix:  "10.1.2.0/24",
+			want:    false,
+		},
+		{
+			name:    "exact length match with no ge/le",
+			entries: []types.MCRPrefixFilterListEntry{{Action: "permit", Prefix: "10.0.0.0/8"}},
+			prefix:  "10.0.0.0/8",
+			want:    true,
+		},
+		{
+			name:    "prefix outside the entry's network is denied",
+			entries: []types.MCRPrefixFilterListEntry{{Action: "permit", Prefix: "10.0.0.0/8", Le: 24}},
+			prefix:  "192.168.1.0/24",
+			want:    false,
+		},
+		{
+			name:    "deny action is honoured on a match",
+			entries: []types.MCRPrefixFilterListEntry{{Action: "deny", Prefix: "10.0.0.0/8", Le: 24}},
+			prefix:  "10.1.2.0/24",
+			want:    false,
+		},
+		{
+			name:    "no matching entry falls back to deny",
+			entries: nil,
+			prefix:  "10.0.0.0/8",
+			want:    false,
+		},
+		{
+			name:    "ipv6 ge extends to /128, not a hardcoded /32",
+			entries: []types.MCRPrefixFilterListEntry{{Action: "permit", Prefix: "2001:db8::/32", Ge: 48}},
+			prefix:  "2001:db8:1::/56",
+			want:    true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			list := types.MCRPrefixFilterList{Entries: tc.entries}
+			if got := prefixFilterListPermits(list, tc.prefix); got != tc.want {
+				t.Errorf("prefixFilterListPermits(%v, %q) = %v, want %v", tc.entries, tc.prefix, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDiffLearnedRoutes(t *testing.T) {
+	m := &MCR{}
+	filterList := types.MCRPrefixFilterList{
+		Entries: []types.MCRPrefixFilterListEntry{
+			{Action: "permit", Prefix: "10.0.0.0/8", Le: 24},
+		},
+	}
+	routes := []types.LearnedRoute{
+		{Prefix: "10.1.2.0/24"},
+		{Prefix: "192.168.0.0/24"},
+	}
+
+	permitted, denied := m.DiffLearnedRoutes(routes, filterList)
+
+	if len(permitted) != 1 || permitted[0].Prefix != "10.1.2.0/24" {
+		t.Errorf("permitted = %v, want just 10.1.2.0/24", permitted)
+	}
+	if len(denied) != 1 || denied[0].Prefix != "192.168.0.0/24" {
+		t.Errorf("denied = %v, want just 192.168.0.0/24", denied)
+	}
+}
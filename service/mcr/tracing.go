@@ -0,0 +1,38 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcr
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer emits the spans BuyMCR, GetMCRDetails, ModifyMCR, DeleteMCR, RestoreMCR and
+// Wait (and so every waiter built on it, including WaitForMcrProvisioning) start.
+// Callers with their own TracerProvider pick it up automatically via
+// otel.SetTracerProvider; there's nothing in this package to wire up beyond that.
+var tracer = otel.Tracer("github.com/megaport/megaportgo/service/mcr")
+
+// recordErr marks span as failed and attaches err, returning err unchanged so callers
+// can do `return x, recordErr(span, err)`. A nil err is a no-op and leaves the span
+// status untouched.
+func recordErr(span trace.Span, err error) error {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
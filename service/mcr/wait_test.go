@@ -0,0 +1,96 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mcr
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWaitOptionsWithDefaults(t *testing.T) {
+	opts := WaitOptions{}.withDefaults()
+
+	if opts.InitialInterval != 10*time.Second {
+		t.Errorf("InitialInterval = %v, want 10s", opts.InitialInterval)
+	}
+	if opts.MaxInterval != 30*time.Second {
+		t.Errorf("MaxInterval = %v, want 30s", opts.MaxInterval)
+	}
+	if opts.Multiplier != 1 {
+		t.Errorf("Multiplier = %v, want 1", opts.Multiplier)
+	}
+	if opts.Jitter != 0 {
+		t.Errorf("Jitter = %v, want 0", opts.Jitter)
+	}
+	if opts.MaxElapsedTime != 5*time.Minute {
+		t.Errorf("MaxElapsedTime = %v, want 5m", opts.MaxElapsedTime)
+	}
+}
+
+func TestWaitOptionsWithDefaultsPreservesSetValues(t *testing.T) {
+	opts := WaitOptions{
+		InitialInterval: time.Second,
+		MaxInterval:     time.Minute,
+		Multiplier:      2,
+		Jitter:          0.5,
+		MaxElapsedTime:  -1,
+	}.withDefaults()
+
+	if opts.InitialInterval != time.Second {
+		t.Errorf("InitialInterval = %v, want 1s", opts.InitialInterval)
+	}
+	if opts.MaxInterval != time.Minute {
+		t.Errorf("MaxInterval = %v, want 1m", opts.MaxInterval)
+	}
+	if opts.Multiplier != 2 {
+		t.Errorf("Multiplier = %v, want 2", opts.Multiplier)
+	}
+	if opts.Jitter != 0.5 {
+		t.Errorf("Jitter = %v, want 0.5", opts.Jitter)
+	}
+	if opts.MaxElapsedTime != -1 {
+		t.Errorf("MaxElapsedTime = %v, want -1 (wait indefinitely)", opts.MaxElapsedTime)
+	}
+}
+
+func TestWaitOptionsNextIntervalAppliesMultiplierAndCap(t *testing.T) {
+	opts := WaitOptions{MaxInterval: 20 * time.Second, Multiplier: 2}.withDefaults()
+
+	if got := opts.nextInterval(5 * time.Second); got != 10*time.Second {
+		t.Errorf("nextInterval(5s) = %v, want 10s", got)
+	}
+	if got := opts.nextInterval(15 * time.Second); got != 20*time.Second {
+		t.Errorf("nextInterval(15s) = %v, want 20s (capped)", got)
+	}
+}
+
+func TestWaitOptionsNextIntervalNoJitterIsDeterministic(t *testing.T) {
+	opts := WaitOptions{MaxInterval: time.Minute, Multiplier: 1}.withDefaults()
+
+	if got := opts.nextInterval(10 * time.Second); got != 10*time.Second {
+		t.Errorf("nextInterval(10s) = %v, want 10s unchanged", got)
+	}
+}
+
+func TestWaitOptionsNextIntervalJitterStaysInRange(t *testing.T) {
+	opts := WaitOptions{MaxInterval: time.Minute, Multiplier: 1, Jitter: 0.5}.withDefaults()
+
+	for i := 0; i < 100; i++ {
+		got := opts.nextInterval(10 * time.Second)
+		if got < 5*time.Second || got > 15*time.Second {
+			t.Fatalf("nextInterval(10s) = %v, want within +/-50%% of 10s", got)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// MCRPrefixFilterList is a named list of prefix match/action entries that can be
+// attached to an MCR's BGP sessions to control which routes are accepted or
+// advertised.
+type MCRPrefixFilterList struct {
+	ID            int                        `json:"id,omitempty"`
+	Description   string                     `json:"description"`
+	AddressFamily string                     `json:"addressFamily"`
+	Entries       []MCRPrefixFilterListEntry `json:"entries"`
+}
+
+// MCRPrefixFilterListEntry is a single permit/deny rule within a MCRPrefixFilterList.
+// Ge and Le express a prefix-length range in the style of `ge`/`le` modifiers on a
+// router's `ip prefix-list`; zero means "not set".
+type MCRPrefixFilterListEntry struct {
+	Action string `json:"action"`
+	Prefix string `json:"prefix"`
+	Ge     int    `json:"ge,omitempty"`
+	Le     int    `json:"le,omitempty"`
+}
+
+// MCRPrefixFilterListResponse is the API envelope returned for a single Prefix Filter
+// List.
+type MCRPrefixFilterListResponse struct {
+	Data MCRPrefixFilterList `json:"data"`
+}
+
+// MCRPrefixFilterListsResponse is the API envelope returned when listing the Prefix
+// Filter Lists configured on an MCR.
+type MCRPrefixFilterListsResponse struct {
+	Data []MCRPrefixFilterList `json:"data"`
+}
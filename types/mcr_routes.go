@@ -0,0 +1,64 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// BGPPeerStatus is the looking-glass view of a single BGP session on an MCR.
+type BGPPeerStatus struct {
+	VxcID            string `json:"vxcId"`
+	PeerIP           string `json:"peerIp"`
+	PeerASN          int    `json:"peerAsn"`
+	State            string `json:"state"`
+	RoutesReceived   int    `json:"routesReceived"`
+	RoutesAdvertised int    `json:"routesAdvertised"`
+	UptimeSeconds    int    `json:"uptimeSeconds"`
+}
+
+// BGPPeerStatusResponse is the API envelope returned by the BGP status endpoint.
+type BGPPeerStatusResponse struct {
+	Data []BGPPeerStatus `json:"data"`
+}
+
+// RouteStrategy selects which routes a learned-route query returns.
+type RouteStrategy string
+
+const (
+	// RouteStrategyAll returns every route in the RIB, including non-best paths.
+	RouteStrategyAll RouteStrategy = "ALL"
+	// RouteStrategyBest returns only the best route selected per prefix.
+	RouteStrategyBest RouteStrategy = "BEST"
+)
+
+// RouteQuery configures a learned-route lookup against an MCR looking glass. VxcID
+// narrows the query to routes learned over a single BGP session; leave it blank to
+// query the whole RIB.
+type RouteQuery struct {
+	VxcID    string
+	Strategy RouteStrategy
+}
+
+// LearnedRoute is a single entry from an MCR's BGP RIB.
+type LearnedRoute struct {
+	Prefix    string `json:"prefix"`
+	NextHop   string `json:"nextHop"`
+	ASPath    []int  `json:"asPath"`
+	MED       int    `json:"med"`
+	LocalPref int    `json:"localPref"`
+	Best      bool   `json:"best"`
+}
+
+// LearnedRoutesResponse is the API envelope returned by the learned-routes endpoint.
+type LearnedRoutesResponse struct {
+	Data []LearnedRoute `json:"data"`
+}